@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/nabidam/baaham/internal/api"
 	"github.com/nabidam/baaham/internal/config"
+	"github.com/nabidam/baaham/internal/domain"
 	"github.com/nabidam/baaham/internal/handler"
 	"github.com/nabidam/baaham/internal/repository"
 	"github.com/nabidam/baaham/internal/service"
@@ -12,6 +15,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
 func main() {
 	cfg := config.Load()
 	defer cfg.Logger.Sync()
@@ -27,11 +33,29 @@ func main() {
 	}
 
 	mainRepo := repository.NewMainRepository(db)
+	go pruneLoginAttempts(mainRepo.LoginAttemptRepository, cfg.Logger)
+
 	mainSvc := service.NewMainService(mainRepo, cfg)
 	mainHandler := handler.NewMainHandler(mainSvc)
 
-	r := api.New(cfg, mainHandler)
+	r := api.New(cfg, mainHandler, mainSvc.AuthService)
 
 	serverAddress := fmt.Sprintf(":%s", cfg.Server.Port)
 	r.Run(serverAddress)
 }
+
+// pruneLoginAttempts drops stale failed-login rows, which are also kept for
+// usernames that do not exist.
+func pruneLoginAttempts(repo domain.LoginAttemptRepository, logger *zap.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		n, err := repo.Prune(context.Background(), time.Now().Add(-24*time.Hour))
+		if err != nil {
+			logger.Error("pruning login attempts failed", zap.Error(err))
+			continue
+		}
+		logger.Debug("pruned login attempts", zap.Int64("rows", n))
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var attemptsCmd = &cobra.Command{
+	Use:   "attempts",
+	Short: "List failed login tracking, including usernames that do not exist",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		attempts, err := attemptRepo.List(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if len(attempts) == 0 {
+			fmt.Print("There are no failed logins in db.")
+			return nil
+		}
+		now := time.Now()
+		for _, a := range attempts {
+			locked := "no"
+			if a.IsLocked(now) {
+				locked = a.LockedUntil.Format(time.RFC3339)
+			}
+			fmt.Printf(
+				"%s | failed=%d | locked=%s | next_attempt=%s | updated=%s\n",
+				a.Username,
+				a.FailedAttempts,
+				locked,
+				a.NextAttemptAt.Format(time.RFC3339),
+				a.UpdatedAt.Format(time.RFC3339),
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(attemptsCmd)
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nabidam/baaham/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var createBotCmd = &cobra.Command{
+	Use:   "create-bot",
+	Short: "Create a bot user",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("username")
+		role, _ := cmd.Flags().GetString("role")
+		if username == "" {
+			return fmt.Errorf("username required")
+		}
+
+		created, err := botSvc.Create(context.Background(), username, role)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf(
+			"Bot created: %s (role=%s, id=%s)\n",
+			created.Username,
+			created.Role,
+			created.ID,
+		)
+
+		return nil
+	},
+}
+
+var createTokenCmd = &cobra.Command{
+	Use:   "create-token",
+	Short: "Create an API token for a bot",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("username")
+		name, _ := cmd.Flags().GetString("name")
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		if username == "" || name == "" {
+			return fmt.Errorf("username and name required")
+		}
+
+		created, err := botSvc.CreateToken(context.Background(), username, name, ttl)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Token created (id=%s). It will not be shown again:\n%s\n", created.ID, created.Token)
+
+		return nil
+	},
+}
+
+var listTokensCmd = &cobra.Command{
+	Use:   "list-tokens",
+	Short: "List a bot's API tokens",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("username")
+		if username == "" {
+			return fmt.Errorf("username required")
+		}
+
+		tokens, err := botSvc.ListTokens(context.Background(), username)
+		if err != nil {
+			return err
+		}
+
+		if len(tokens) == 0 {
+			fmt.Print("Bot has no tokens.")
+			return nil
+		}
+		for _, t := range tokens {
+			expires, lastUsed := "never", "never"
+			if t.ExpiresAt != nil {
+				expires = t.ExpiresAt.Format(time.RFC3339)
+			}
+			if t.LastUsedAt != nil {
+				lastUsed = t.LastUsedAt.Format(time.RFC3339)
+			}
+			fmt.Printf(
+				"%s | %s | expires=%s | last_used=%s | created=%s\n",
+				t.ID,
+				t.Name,
+				expires,
+				lastUsed,
+				t.CreatedAt.Format("2006-01-02"),
+			)
+		}
+		return nil
+	},
+}
+
+var revokeTokenCmd = &cobra.Command{
+	Use:   "revoke-token",
+	Short: "Revoke a bot's API token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("username")
+		id, _ := cmd.Flags().GetString("id")
+		if username == "" || id == "" {
+			return fmt.Errorf("username and id required")
+		}
+		if err := botSvc.RevokeToken(context.Background(), username, id); err != nil {
+			return err
+		}
+
+		fmt.Print("Token revoked.")
+
+		return nil
+	},
+}
+
+func init() {
+	createBotCmd.Flags().StringP("username", "u", "", "bot username")
+	createBotCmd.Flags().StringP("role", "r", domain.RoleBot, "role")
+	createTokenCmd.Flags().StringP("username", "u", "", "bot username")
+	createTokenCmd.Flags().StringP("name", "n", "", "token name")
+	createTokenCmd.Flags().Duration("ttl", 0, "token lifetime (0 = never expires)")
+	listTokensCmd.Flags().StringP("username", "u", "", "bot username")
+	revokeTokenCmd.Flags().StringP("username", "u", "", "bot username")
+	revokeTokenCmd.Flags().String("id", "", "token id")
+	rootCmd.AddCommand(createBotCmd)
+	rootCmd.AddCommand(createTokenCmd)
+	rootCmd.AddCommand(listTokensCmd)
+	rootCmd.AddCommand(revokeTokenCmd)
+}
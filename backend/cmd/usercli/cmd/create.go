@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"syscall"
 
+	"github.com/nabidam/baaham/internal/domain"
 	"github.com/nabidam/baaham/pkg/password"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -15,11 +16,18 @@ var createCmd = &cobra.Command{
 	Short: "Create a new user",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		username, _ := cmd.Flags().GetString("username")
+		role, _ := cmd.Flags().GetString("role")
 		isAdmin, _ := cmd.Flags().GetBool("admin")
 
 		if username == "" {
 			return fmt.Errorf("username required")
 		}
+		if isAdmin {
+			role = domain.RoleAdmin
+		}
+		if _, err := roleRepo.Get(context.Background(), role); err != nil {
+			return fmt.Errorf("role %q: %w", role, err)
+		}
 
 		fmt.Print("Password: ")
 		pass, err := term.ReadPassword(int(syscall.Stdin))
@@ -37,16 +45,16 @@ var createCmd = &cobra.Command{
 			context.Background(),
 			username,
 			hash,
-			isAdmin,
+			role,
 		)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf(
-			"User created: %s (admin=%v, id=%s)\n",
+			"User created: %s (role=%s, id=%s)\n",
 			created.Username,
-			created.IsAdmin,
+			created.Role,
 			created.ID,
 		)
 
@@ -56,6 +64,7 @@ var createCmd = &cobra.Command{
 
 func init() {
 	createCmd.Flags().StringP("username", "u", "", "username")
-	createCmd.Flags().Bool("admin", false, "is admin")
+	createCmd.Flags().StringP("role", "r", domain.RoleMember, "role")
+	createCmd.Flags().Bool("admin", false, "shorthand for --role admin")
 	rootCmd.AddCommand(createCmd)
 }
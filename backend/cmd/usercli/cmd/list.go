@@ -3,7 +3,10 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/nabidam/baaham/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -20,11 +23,33 @@ var listCmd = &cobra.Command{
 			fmt.Print("There is no user in db.")
 			return nil
 		}
+		attempts, err := attemptRepo.List(context.Background())
+		if err != nil {
+			return err
+		}
+		byUsername := make(map[string]domain.LoginAttempt, len(attempts))
+		for _, a := range attempts {
+			byUsername[a.Username] = a
+		}
+
+		now := time.Now()
 		for _, u := range users {
+			locked := "no"
+			if u.IsLocked(now) {
+				locked = u.LockedUntil.Format(time.RFC3339)
+			}
+			// lockouts from failed logins are kept apart from admin locks
+			a := byUsername[strings.ToLower(u.Username)]
+			if a.IsLocked(now) && !u.IsLocked(now) {
+				locked = a.LockedUntil.Format(time.RFC3339) + " (failed logins)"
+			}
 			fmt.Printf(
-				"%s | admin=%v | created=%s\n",
+				"%s | role=%s | bot=%v | locked=%s | failed=%d | created=%s\n",
 				u.Username,
-				u.IsAdmin,
+				u.Role,
+				u.IsBot,
+				locked,
+				a.FailedAttempts,
 				u.CreatedAt.Format("2006-01-02"),
 			)
 		}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Lock a user out of login and API tokens",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("username")
+		duration, _ := cmd.Flags().GetDuration("duration")
+		if username == "" {
+			return fmt.Errorf("username required")
+		}
+
+		until := time.Now().Add(duration)
+		err := repo.Lock(context.Background(), username, until)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("User locked until %s.\n", until.Format(time.RFC3339))
+
+		return nil
+	},
+}
+
+var unlockCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "Clear a user's lock and failed login attempts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("username")
+		if username == "" {
+			return fmt.Errorf("username required")
+		}
+
+		err := repo.Unlock(context.Background(), username)
+		if err != nil {
+			return err
+		}
+
+		if err := attemptRepo.Reset(context.Background(), strings.ToLower(username)); err != nil {
+			return err
+		}
+
+		fmt.Print("User unlocked.")
+
+		return nil
+	},
+}
+
+func init() {
+	lockCmd.Flags().StringP("username", "u", "", "username")
+	lockCmd.Flags().DurationP("duration", "d", 24*time.Hour, "lock duration")
+	unlockCmd.Flags().StringP("username", "u", "", "username")
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nabidam/baaham/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var setRoleCmd = &cobra.Command{
+	Use:   "set-role",
+	Short: "Assign a role to a user",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, _ := cmd.Flags().GetString("username")
+		role, _ := cmd.Flags().GetString("role")
+		if username == "" || role == "" {
+			return fmt.Errorf("username and role required")
+		}
+
+		if err := userSvc.SetRole(context.Background(), username, role); err != nil {
+			return err
+		}
+
+		fmt.Printf("User role set to %s.", role)
+
+		return nil
+	},
+}
+
+var rolesCmd = &cobra.Command{
+	Use:   "roles",
+	Short: "List roles and their permissions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		roles, err := roleRepo.List(context.Background())
+		if err != nil {
+			return err
+		}
+
+		for _, r := range roles {
+			perms := make([]string, 0, len(r.Permissions))
+			for _, p := range r.Permissions {
+				perms = append(perms, string(p))
+			}
+			fmt.Printf("%s | %s\n", r.Name, strings.Join(perms, ", "))
+		}
+		return nil
+	},
+}
+
+var grantCmd = &cobra.Command{
+	Use:   "grant",
+	Short: "Grant a permission to a role",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateRolePermission(cmd, func(perms []domain.Permission, p domain.Permission) []domain.Permission {
+			if slices.Contains(perms, p) {
+				return perms
+			}
+			return append(perms, p)
+		})
+	},
+}
+
+var revokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke a permission from a role",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateRolePermission(cmd, func(perms []domain.Permission, p domain.Permission) []domain.Permission {
+			return slices.DeleteFunc(perms, func(existing domain.Permission) bool {
+				return existing == p
+			})
+		})
+	},
+}
+
+func updateRolePermission(cmd *cobra.Command, update func([]domain.Permission, domain.Permission) []domain.Permission) error {
+	roleName, _ := cmd.Flags().GetString("role")
+	permission, _ := cmd.Flags().GetString("permission")
+	if roleName == "" || permission == "" {
+		return fmt.Errorf("role and permission required")
+	}
+
+	p, err := domain.ParsePermission(permission)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, permission)
+	}
+
+	ctx := context.Background()
+	role, err := roleRepo.Get(ctx, roleName)
+	if err != nil {
+		return err
+	}
+
+	perms := make([]string, 0, len(role.Permissions)+1)
+	for _, perm := range update(role.Permissions, p) {
+		perms = append(perms, string(perm))
+	}
+
+	if _, err := roleSvc.SetPermissions(ctx, roleName, perms); err != nil {
+		return err
+	}
+
+	fmt.Print("Role permissions updated.")
+	return nil
+}
+
+func init() {
+	setRoleCmd.Flags().StringP("username", "u", "", "username")
+	setRoleCmd.Flags().StringP("role", "r", "", "role")
+	grantCmd.Flags().StringP("role", "r", "", "role")
+	grantCmd.Flags().StringP("permission", "p", "", "permission")
+	revokeCmd.Flags().StringP("role", "r", "", "role")
+	revokeCmd.Flags().StringP("permission", "p", "", "permission")
+	rootCmd.AddCommand(setRoleCmd)
+	rootCmd.AddCommand(rolesCmd)
+	rootCmd.AddCommand(grantCmd)
+	rootCmd.AddCommand(revokeCmd)
+}
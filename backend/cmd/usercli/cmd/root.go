@@ -6,13 +6,23 @@ import (
 	"github.com/nabidam/baaham/internal/config"
 	"github.com/nabidam/baaham/internal/domain"
 	"github.com/nabidam/baaham/internal/repository"
+	"github.com/nabidam/baaham/internal/service"
 	"github.com/nabidam/baaham/pkg/database"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
 var (
-	repo domain.UserRepository
+	repo        domain.UserRepository
+	roleRepo    domain.RoleRepository
+	tokenRepo   domain.APITokenRepository
+	attemptRepo domain.LoginAttemptRepository
+
+	// Services hold the rules shared with the HTTP API; commands that change
+	// roles or bots go through them rather than the repositories.
+	userSvc domain.UserService
+	roleSvc domain.RoleService
+	botSvc  domain.BotService
 )
 
 var rootCmd = &cobra.Command{
@@ -28,6 +38,13 @@ var rootCmd = &cobra.Command{
 		}
 
 		repo = repository.NewUserRepository(db)
+		roleRepo = repository.NewRoleRepository(db)
+		tokenRepo = repository.NewAPITokenRepository(db)
+		attemptRepo = repository.NewLoginAttemptRepository(db)
+
+		userSvc = service.NewUserService(repo, roleRepo)
+		roleSvc = service.NewRoleService(roleRepo, repo)
+		botSvc = service.NewBotService(repo, roleRepo, tokenRepo)
 		return nil
 	},
 }
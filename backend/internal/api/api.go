@@ -5,6 +5,7 @@ import (
 
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -12,23 +13,32 @@ import (
 	docs "github.com/nabidam/baaham/cmd/docs"
 
 	"github.com/nabidam/baaham/internal/config"
+	"github.com/nabidam/baaham/internal/domain"
 	"github.com/nabidam/baaham/internal/handler"
+	"github.com/nabidam/baaham/internal/middleware"
 	"github.com/nabidam/baaham/internal/route"
 )
 
 func New(
 	cfg *config.Config,
 	h *handler.MainHandler,
+	authSvc domain.AuthService,
 ) *gin.Engine {
 	r := gin.New()
 	docs.SwaggerInfo.BasePath = "/api/v1"
 
+	// gin trusts X-Forwarded-For from anyone by default, which would let
+	// clients pick their own IP for rate limiting
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		cfg.Logger.Fatal("invalid trusted proxies", zap.Error(err))
+	}
+
 	// log all requests
 	r.Use(ginzap.Ginzap(cfg.Logger, time.RFC3339, true))
 	// log panics
 	r.Use(ginzap.RecoveryWithZap(cfg.Logger, true))
 
-	route.RegisterRoutes(r, h)
+	route.RegisterRoutes(r, h, middleware.Auth(authSvc), cfg)
 
 	// swagger route
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -12,6 +13,9 @@ import (
 type Config struct {
 	Server struct {
 		Port string
+		// Proxies allowed to set X-Forwarded-For. Empty means the client IP
+		// is always the connection's remote address.
+		TrustedProxies []string
 	}
 
 	Database struct {
@@ -23,6 +27,16 @@ type Config struct {
 		DSN      string
 	}
 
+	Auth struct {
+		// Failed logins allowed before the account is temporarily locked.
+		MaxFailedLogins int
+		LockoutDuration time.Duration
+		// Base of the exponential wait enforced between failed logins.
+		LoginDelayBase time.Duration
+		// Login attempts allowed per minute, per client IP and per username.
+		LoginRateLimit int
+	}
+
 	AppEnv    string
 	JWTSecret string
 
@@ -41,6 +55,10 @@ func Load() *Config {
 	v.SetDefault("SERVER_PORT", "8080")
 	v.SetDefault("DATABASE_HOST", "localhost")
 	v.SetDefault("DATABASE_PORT", "5432")
+	v.SetDefault("AUTH_MAX_FAILED_LOGINS", 10)
+	v.SetDefault("AUTH_LOCKOUT_DURATION", "15m")
+	v.SetDefault("AUTH_LOGIN_DELAY_BASE", "1s")
+	v.SetDefault("AUTH_LOGIN_RATE_LIMIT", 10)
 
 	if err := v.ReadInConfig(); err != nil {
 		log.Println("config: no .env file found, relying on env vars")
@@ -54,6 +72,7 @@ func Load() *Config {
 	}
 
 	cfg.Server.Port = v.GetString("SERVER_PORT")
+	cfg.Server.TrustedProxies = splitList(v.GetString("SERVER_TRUSTED_PROXIES"))
 
 	cfg.Database.Host = v.GetString("DATABASE_HOST")
 	cfg.Database.Port = v.GetString("DATABASE_PORT")
@@ -66,6 +85,11 @@ func Load() *Config {
 		cfg.Database.UserName, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName,
 	)
 
+	cfg.Auth.MaxFailedLogins = v.GetInt("AUTH_MAX_FAILED_LOGINS")
+	cfg.Auth.LockoutDuration = v.GetDuration("AUTH_LOCKOUT_DURATION")
+	cfg.Auth.LoginDelayBase = v.GetDuration("AUTH_LOGIN_DELAY_BASE")
+	cfg.Auth.LoginRateLimit = v.GetInt("AUTH_LOGIN_RATE_LIMIT")
+
 	cfg.JWTSecret = v.GetString("JWT_SECRET")
 
 	validate(cfg)
@@ -78,6 +102,17 @@ func Load() *Config {
 	return cfg
 }
 
+// splitList parses a comma separated env value, dropping empty entries.
+func splitList(value string) []string {
+	items := []string{}
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func buildLogger(env string) *zap.Logger {
 	if env == "production" {
 		logger, _ := zap.NewProduction()
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrAPITokenNotFound = errors.New("api token not found")
+	ErrNotBot           = errors.New("user is not a bot")
+	// Bots authenticate with long-lived tokens, so they may not hold roles
+	// that can manage users.
+	ErrBotRoleNotAllowed = errors.New("bots cannot have a role with manage_users")
+)
+
+type APIToken struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreatedAPIToken is returned once on creation; only the hash is stored.
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+type APITokenRepository interface {
+	Create(ctx context.Context, userID string, name string, tokenHash string, expiresAt *time.Time) (*APIToken, error)
+	ListByUser(ctx context.Context, userID string) ([]APIToken, error)
+	Delete(ctx context.Context, userID string, id string) error
+	// Authenticate returns the owner of an unexpired token and records its use.
+	Authenticate(ctx context.Context, tokenHash string) (*User, error)
+}
+
+type BotService interface {
+	Create(ctx context.Context, username string, role string) (*User, error)
+	CreateToken(ctx context.Context, username string, name string, ttl time.Duration) (*CreatedAPIToken, error)
+	ListTokens(ctx context.Context, username string) ([]APIToken, error)
+	RevokeToken(ctx context.Context, username string, id string) error
+}
+
+type CreateBotRequest struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role"`
+}
+
+type CreateAPITokenRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Lifetime in hours; 0 means the token never expires.
+	ExpiresInHours int `json:"expires_in_hours" binding:"min=0"`
+}
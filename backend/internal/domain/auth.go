@@ -2,23 +2,49 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrAccountLocked      = errors.New("account temporarily locked")
+	ErrTooManyAttempts    = errors.New("too many login attempts")
+	ErrInvalidToken       = errors.New("invalid token")
+)
+
+// UserClaims is what a login JWT carries: who the user is, nothing about
+// what they may do.
 type UserClaims struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
-	IsAdmin  bool   `json:"is_admin"`
 	jwt.RegisteredClaims
 }
 
+// Identity is the authenticated caller of a request, with the role and
+// permissions read from the database when the request came in.
+type Identity struct {
+	UserID      string
+	Username    string
+	Role        string
+	Permissions []Permission
+	Bot         bool
+}
+
+func (i *Identity) Can(p Permission) bool {
+	return slices.Contains(i.Permissions, p)
+}
+
 // type AuthRepository interface {
 // 	CheckCredentials(ctx context.Context, username string, passwordHash string) (*User, error)
 // }
 
 type AuthService interface {
 	Login(ctx context.Context, username string, password string) (*LoginResponse, error)
+	// Authenticate resolves a bearer token, either a login JWT or a bot API token.
+	Authenticate(ctx context.Context, token string) (*Identity, error)
 }
 
 type LoginRequest struct {
@@ -29,3 +55,11 @@ type LoginRequest struct {
 type LoginResponse struct {
 	Token string `json:"token"`
 }
+
+type MeResponse struct {
+	UserID      string       `json:"user_id"`
+	Username    string       `json:"username"`
+	Role        string       `json:"role"`
+	Permissions []Permission `json:"permissions"`
+	Bot         bool         `json:"bot"`
+}
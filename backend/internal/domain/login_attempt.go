@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type LoginAttempt struct {
+	Username       string     `json:"username" db:"username"`
+	FailedAttempts int        `json:"failed_attempts" db:"failed_attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" db:"next_attempt_at"`
+	LockedUntil    *time.Time `json:"locked_until" db:"locked_until"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsLocked reports whether the username is locked out by failed logins.
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// LoginAttemptRepository tracks failed logins per lowercased username. Rows
+// exist for unknown usernames too, so both cases cost the same.
+type LoginAttemptRepository interface {
+	// Begin reserves a login attempt for username. It returns
+	// ErrAccountLocked or ErrTooManyAttempts if an attempt is not allowed
+	// yet. Otherwise it atomically pushes the next allowed attempt out by
+	// delay(failedAttempts+1), so parallel requests cannot skip the wait.
+	Begin(ctx context.Context, username string, delay func(failedAttempts int) time.Duration) error
+	// RecordFailure bumps the failed attempt counter and returns its new value.
+	RecordFailure(ctx context.Context, username string) (int, error)
+	// Lock locks username until the given time and resets the counter.
+	Lock(ctx context.Context, username string, until time.Time) error
+	// Reset forgets all failures for username.
+	Reset(ctx context.Context, username string) error
+	// List returns every tracked username, most recently updated first.
+	List(ctx context.Context) ([]LoginAttempt, error)
+	// Prune deletes unlocked rows not touched since before.
+	Prune(ctx context.Context, before time.Time) (int64, error)
+}
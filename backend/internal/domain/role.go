@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrLastAdmin         = errors.New("change would leave no user with manage_users")
+)
+
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleMember    = "member"
+	RoleGuest     = "guest"
+	RoleBot       = "bot"
+)
+
+type Permission string
+
+const (
+	PermManageUsers  Permission = "manage_users"
+	PermManageRooms  Permission = "manage_rooms"
+	PermManageMedia  Permission = "manage_media"
+	PermUpload       Permission = "upload"
+	PermModerateChat Permission = "moderate_chat"
+	PermJoinRooms    Permission = "join_rooms"
+)
+
+var AllPermissions = []Permission{
+	PermManageUsers,
+	PermManageRooms,
+	PermManageMedia,
+	PermUpload,
+	PermModerateChat,
+	PermJoinRooms,
+}
+
+// ParsePermission validates a permission name against AllPermissions.
+func ParsePermission(name string) (Permission, error) {
+	p := Permission(name)
+	if !slices.Contains(AllPermissions, p) {
+		return "", ErrUnknownPermission
+	}
+	return p, nil
+}
+
+type Role struct {
+	Name        string       `json:"name" db:"name"`
+	Permissions []Permission `json:"permissions"`
+}
+
+type RoleRepository interface {
+	List(ctx context.Context) ([]Role, error)
+	Get(ctx context.Context, name string) (*Role, error)
+	// SetPermissions replaces the role's permission set. It fails with
+	// ErrLastAdmin if that would leave no unlocked user with manage_users.
+	SetPermissions(ctx context.Context, name string, permissions []Permission) error
+}
+
+type RoleService interface {
+	List(ctx context.Context) ([]Role, error)
+	SetPermissions(ctx context.Context, name string, permissions []string) (*Role, error)
+}
+
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
@@ -2,26 +2,62 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserExists   = errors.New("user already exists")
+)
+
 type User struct {
-	ID           string    `db:"id"`
-	Username     string    `db:"username"`
-	PasswordHash string    `db:"password_hash"`
-	IsAdmin      bool      `db:"is_admin"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+	ID           string     `json:"id" db:"id"`
+	Username     string     `json:"username" db:"username"`
+	PasswordHash string     `json:"-" db:"password_hash"`
+	Role         string     `json:"role" db:"role"`
+	IsBot        bool       `json:"is_bot" db:"is_bot"`
+	LockedUntil  *time.Time `json:"locked_until" db:"locked_until"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsLocked reports whether an admin has locked the account at the given time.
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
 
 type UserRepository interface {
-	Create(ctx context.Context, username string, passwordHash string, isAdmin bool) (*User, error)
+	Create(ctx context.Context, username string, passwordHash string, role string) (*User, error)
+	// CreateBot creates a bot user. Bots have no password and authenticate with API tokens.
+	CreateBot(ctx context.Context, username string, role string) (*User, error)
 	List(ctx context.Context) ([]User, error)
 	UpdatePassword(ctx context.Context, username string, passwordHash string) error
+	// SetRole fails with ErrLastAdmin if the change would leave no unlocked
+	// user with manage_users.
+	SetRole(ctx context.Context, username string, role string) error
+	CountBotsWithRole(ctx context.Context, role string) (int, error)
 	Delete(ctx context.Context, username string) error
 	GetByUsername(ctx context.Context, username string) (*User, error)
+
+	// Lock locks the account until the given time, blocking both password
+	// logins and API tokens.
+	Lock(ctx context.Context, username string, until time.Time) error
+	Unlock(ctx context.Context, username string) error
 }
 
 type UserService interface {
-	Create(ctx context.Context, username string, password string, isAdmin bool) (User, error)
+	Create(ctx context.Context, username string, password string, role string) (*User, error)
+	List(ctx context.Context) ([]User, error)
+	SetRole(ctx context.Context, username string, role string) error
+}
+
+type CreateUserRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Role     string `json:"role"`
+}
+
+type SetUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
 }
@@ -0,0 +1,12 @@
+package domain
+
+import "regexp"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID reports whether s is a UUID in its canonical hyphenated form. Ids
+// are checked before they reach a uuid column so a malformed one reads as
+// "not found" rather than a database error.
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "testing"
+
+func TestIsUUID(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"3f2504e0-4f89-41d3-9a0c-0305e82c3301", true},
+		{"3F2504E0-4F89-41D3-9A0C-0305E82C3301", true},
+		{"", false},
+		{"42", false},
+		{"3f2504e04f8941d39a0c0305e82c3301", false},
+		{"3f2504e0-4f89-41d3-9a0c-0305e82c330z", false},
+		{"3f2504e0-4f89-41d3-9a0c-0305e82c3301 ", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsUUID(tt.in); got != tt.want {
+			t.Errorf("IsUUID(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+type AdminHandler struct {
+	userSvc domain.UserService
+	roleSvc domain.RoleService
+}
+
+func NewAdminHandler(userSvc domain.UserService, roleSvc domain.RoleService) *AdminHandler {
+	return &AdminHandler{userSvc: userSvc, roleSvc: roleSvc}
+}
+
+// @Summary	List users
+// @Schemes
+// @Description	List all users with their roles
+// @Tags			Admin
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}	domain.User
+// @Router			/admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	users, err := h.userSvc.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed"})
+		return
+	}
+
+	if users == nil {
+		users = []domain.User{}
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+// @Summary	Create user
+// @Schemes
+// @Description	Create a user with the given role (defaults to member)
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			user	body		domain.CreateUserRequest	true	"User"
+// @Success		201		{object}	domain.User
+// @Router			/admin/users [post]
+func (h *AdminHandler) CreateUser(c *gin.Context) {
+	var req domain.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	user, err := h.userSvc.Create(c.Request.Context(), req.Username, req.Password, req.Role)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// @Summary	Set user role
+// @Schemes
+// @Description	Assign a role to a user
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			username	path	string						true	"Username"
+// @Param			role		body	domain.SetUserRoleRequest	true	"Role"
+// @Success		204
+// @Router			/admin/users/{username}/role [put]
+func (h *AdminHandler) SetUserRole(c *gin.Context) {
+	var req domain.SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.userSvc.SetRole(c.Request.Context(), c.Param("username"), req.Role); err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary	List roles
+// @Schemes
+// @Description	List roles and their permissions
+// @Tags			Admin
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}	domain.Role
+// @Router			/admin/roles [get]
+func (h *AdminHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleSvc.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed"})
+		return
+	}
+
+	if roles == nil {
+		roles = []domain.Role{}
+	}
+	c.JSON(http.StatusOK, roles)
+}
+
+// @Summary	Set role permissions
+// @Schemes
+// @Description	Replace the permission set of a role
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			role		path		string								true	"Role name"
+// @Param			permissions	body		domain.SetRolePermissionsRequest	true	"Permissions"
+// @Success		200			{object}	domain.Role
+// @Router			/admin/roles/{role}/permissions [put]
+func (h *AdminHandler) SetRolePermissions(c *gin.Context) {
+	var req domain.SetRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	role, err := h.roleSvc.SetPermissions(c.Request.Context(), c.Param("role"), req.Permissions)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+func respondAdminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrRoleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUserExists), errors.Is(err, domain.ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUnknownPermission), errors.Is(err, domain.ErrBotRoleNotAllowed):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed"})
+	}
+}
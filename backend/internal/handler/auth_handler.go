@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nabidam/baaham/internal/domain"
+	"github.com/nabidam/baaham/internal/middleware"
 )
 
 type AuthHandler struct {
@@ -23,6 +25,7 @@ func NewAuthHandler(svc domain.AuthService) *AuthHandler {
 // @Produce		json
 // @Param			login	body		domain.LoginRequest	true	"Login credentials"
 // @Success		200		{object}	domain.LoginResponse
+// @Failure		429		{object}	map[string]string
 // @Produce		json
 // @Router			/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -33,6 +36,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	resp, err := h.svc.Login(c.Request.Context(), req.Username, req.Password)
+	if errors.Is(err, domain.ErrAccountLocked) || errors.Is(err, domain.ErrTooManyAttempts) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many login attempts, try again later"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Wrong credentials"})
 		return
@@ -40,3 +47,27 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// @Summary	Current identity
+// @Schemes
+// @Description	Return the user or bot the bearer token belongs to
+// @Tags			Auth
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	domain.MeResponse
+// @Router			/auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	identity := middleware.Identity(c)
+	if identity == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.MeResponse{
+		UserID:      identity.UserID,
+		Username:    identity.Username,
+		Role:        identity.Role,
+		Permissions: identity.Permissions,
+		Bot:         identity.Bot,
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+type BotHandler struct {
+	svc domain.BotService
+}
+
+func NewBotHandler(svc domain.BotService) *BotHandler {
+	return &BotHandler{svc: svc}
+}
+
+// @Summary	Create bot
+// @Schemes
+// @Description	Create a bot user with the given role (defaults to bot)
+// @Tags			Bots
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			bot	body		domain.CreateBotRequest	true	"Bot"
+// @Success		201	{object}	domain.User
+// @Router			/admin/bots [post]
+func (h *BotHandler) CreateBot(c *gin.Context) {
+	var req domain.CreateBotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	bot, err := h.svc.Create(c.Request.Context(), req.Username, req.Role)
+	if err != nil {
+		respondBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, bot)
+}
+
+// @Summary	List bot tokens
+// @Schemes
+// @Description	List a bot's API tokens
+// @Tags			Bots
+// @Produce		json
+// @Security		BearerAuth
+// @Param			username	path	string	true	"Bot username"
+// @Success		200			{array}	domain.APIToken
+// @Router			/admin/bots/{username}/tokens [get]
+func (h *BotHandler) ListTokens(c *gin.Context) {
+	tokens, err := h.svc.ListTokens(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		respondBotError(c, err)
+		return
+	}
+
+	if tokens == nil {
+		tokens = []domain.APIToken{}
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// @Summary	Create bot token
+// @Schemes
+// @Description	Create an API token for a bot. The token is only returned once.
+// @Tags			Bots
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			username	path		string							true	"Bot username"
+// @Param			token		body		domain.CreateAPITokenRequest	true	"Token"
+// @Success		201			{object}	domain.CreatedAPIToken
+// @Router			/admin/bots/{username}/tokens [post]
+func (h *BotHandler) CreateToken(c *gin.Context) {
+	var req domain.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	ttl := time.Duration(req.ExpiresInHours) * time.Hour
+	token, err := h.svc.CreateToken(c.Request.Context(), c.Param("username"), req.Name, ttl)
+	if err != nil {
+		respondBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// @Summary	Revoke bot token
+// @Schemes
+// @Description	Delete one of a bot's API tokens
+// @Tags			Bots
+// @Security		BearerAuth
+// @Param			username	path	string	true	"Bot username"
+// @Param			id			path	string	true	"Token ID"
+// @Success		204
+// @Router			/admin/bots/{username}/tokens/{id} [delete]
+func (h *BotHandler) RevokeToken(c *gin.Context) {
+	if err := h.svc.RevokeToken(c.Request.Context(), c.Param("username"), c.Param("id")); err != nil {
+		respondBotError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondBotError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotBot):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrAPITokenNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		respondAdminError(c, err)
+	}
+}
@@ -5,11 +5,20 @@ import "github.com/nabidam/baaham/internal/service"
 type MainHandler struct {
 	HealthHandler *HealthHandler
 	AuthHandler   *AuthHandler
+	AdminHandler  *AdminHandler
+	BotHandler    *BotHandler
 }
 
 func NewMainHandler(mainSvc *service.MainService) *MainHandler {
 	healthHandler := NewHealthHandler(mainSvc.HealthService)
 	authHandler := NewAuthHandler(mainSvc.AuthService)
+	adminHandler := NewAdminHandler(mainSvc.UserService, mainSvc.RoleService)
+	botHandler := NewBotHandler(mainSvc.BotService)
 
-	return &MainHandler{HealthHandler: healthHandler, AuthHandler: authHandler}
+	return &MainHandler{
+		HealthHandler: healthHandler,
+		AuthHandler:   authHandler,
+		AdminHandler:  adminHandler,
+		BotHandler:    botHandler,
+	}
 }
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+const identityKey = "identity"
+
+// Auth validates the bearer token (login JWT or bot API token) and stores
+// the caller's identity on the context.
+func Auth(svc domain.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
+			return
+		}
+
+		identity, err := svc.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		c.Set(identityKey, identity)
+		c.Next()
+	}
+}
+
+// RequirePermission must run after Auth.
+func RequirePermission(p domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity := Identity(c)
+		if identity == nil || !identity.Can(p) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Identity returns the identity stored by Auth, or nil.
+func Identity(c *gin.Context) *domain.Identity {
+	v, ok := c.Get(identityKey)
+	if !ok {
+		return nil
+	}
+	identity, _ := v.(*domain.Identity)
+	return identity
+}
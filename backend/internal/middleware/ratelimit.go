@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabidam/baaham/pkg/ratelimit"
+)
+
+// maxLoginBody caps how much of a login body is buffered to read the username.
+const maxLoginBody = 64 << 10
+
+// KeyFunc extracts the rate limit key from a request. An empty key skips
+// limiting for that request.
+type KeyFunc func(c *gin.Context) string
+
+func RateLimit(l *ratelimit.Limiter, key KeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		k := key(c)
+		if k == "" {
+			c.Next()
+			return
+		}
+
+		if ok, wait := l.Allow(k); !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ClientIPKey(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// LoginUsernameKey keys on the username in a JSON login body. The body is
+// restored so the handler can still bind it.
+func LoginUsernameKey(c *gin.Context) string {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxLoginBody))
+	if err != nil {
+		return ""
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var req struct {
+		Username string `json:"username"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil || req.Username == "" {
+		return ""
+	}
+
+	return "user:" + strings.ToLower(req.Username)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+// adminGuardLock is the advisory lock key taken by every change that can
+// take manage_users away from users, so concurrent demotions are checked one
+// after the other instead of all passing against the same count. The value
+// is arbitrary; it only has to be unique among advisory locks in the app.
+const adminGuardLock int64 = 4201
+
+// keepAdmin runs change inside tx and fails with ErrLastAdmin if it leaves no
+// unlocked user with manage_users where there was at least one before.
+func keepAdmin(ctx context.Context, tx pgx.Tx, change func() error) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, adminGuardLock); err != nil {
+		return err
+	}
+
+	before, err := countAdmins(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	after, err := countAdmins(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if before > 0 && after == 0 {
+		return domain.ErrLastAdmin
+	}
+	return nil
+}
+
+// countAdmins counts users able to manage users right now. Bots cannot hold
+// manage_users and locked accounts cannot use it, so neither counts.
+func countAdmins(ctx context.Context, tx pgx.Tx) (int, error) {
+	var count int
+	err := tx.QueryRow(ctx, `
+		SELECT count(*)
+		FROM users u
+		JOIN role_permissions rp ON rp.role = u.role
+		WHERE rp.permission = $1
+			AND NOT u.is_bot
+			AND (u.locked_until IS NULL OR u.locked_until <= now())
+	`, string(domain.PermManageUsers)).Scan(&count)
+	return count, err
+}
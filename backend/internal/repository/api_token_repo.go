@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+type APITokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAPITokenRepository(db *pgxpool.Pool) domain.APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+func (repo *APITokenRepository) Create(ctx context.Context, userID string, name string, tokenHash string, expiresAt *time.Time) (*domain.APIToken, error) {
+	var t domain.APIToken
+	err := repo.db.QueryRow(ctx, `
+		INSERT INTO api_tokens (user_id, name, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, name, last_used_at, expires_at, created_at
+	`, userID, name, tokenHash, expiresAt).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Name,
+		&t.LastUsedAt,
+		&t.ExpiresAt,
+		&t.CreatedAt,
+	)
+	return &t, err
+}
+
+func (repo *APITokenRepository) ListByUser(ctx context.Context, userID string) ([]domain.APIToken, error) {
+	rows, err := repo.db.Query(ctx, `
+		SELECT id, user_id, name, last_used_at, expires_at, created_at
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []domain.APIToken
+	for rows.Next() {
+		var t domain.APIToken
+		if err := rows.Scan(
+			&t.ID,
+			&t.UserID,
+			&t.Name,
+			&t.LastUsedAt,
+			&t.ExpiresAt,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+func (repo *APITokenRepository) Delete(ctx context.Context, userID string, id string) error {
+	cmd, err := repo.db.Exec(ctx, `
+		DELETE FROM api_tokens WHERE user_id = $1 AND id = $2
+	`, userID, id)
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return domain.ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+func (repo *APITokenRepository) Authenticate(ctx context.Context, tokenHash string) (*domain.User, error) {
+	var u domain.User
+	err := repo.db.QueryRow(ctx, `
+		WITH t AS (
+			UPDATE api_tokens
+			SET last_used_at = now()
+			WHERE token_hash = $1 AND (expires_at IS NULL OR expires_at > now())
+			RETURNING user_id
+		)
+		SELECT u.id, u.username, u.password_hash, u.role, u.is_bot, u.locked_until, u.created_at, u.updated_at
+		FROM users u
+		JOIN t ON t.user_id = u.id
+		WHERE u.locked_until IS NULL OR u.locked_until < now()
+	`, tokenHash).Scan(
+		&u.ID,
+		&u.Username,
+		&u.PasswordHash,
+		&u.Role,
+		&u.IsBot,
+		&u.LockedUntil,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrAPITokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+type LoginAttemptRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginAttemptRepository(db *pgxpool.Pool) domain.LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (repo *LoginAttemptRepository) Begin(ctx context.Context, username string, delay func(failedAttempts int) time.Duration) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO login_attempts (username)
+		VALUES ($1)
+		ON CONFLICT (username) DO NOTHING
+	`, username); err != nil {
+		return err
+	}
+
+	var (
+		failedAttempts int
+		nextAttemptAt  time.Time
+		lockedUntil    *time.Time
+		now            time.Time
+	)
+	// the row lock serializes concurrent attempts for the same username
+	if err := tx.QueryRow(ctx, `
+		SELECT failed_attempts, next_attempt_at, locked_until, now()
+		FROM login_attempts
+		WHERE username = $1
+		FOR UPDATE
+	`, username).Scan(&failedAttempts, &nextAttemptAt, &lockedUntil, &now); err != nil {
+		return err
+	}
+
+	if lockedUntil != nil && now.Before(*lockedUntil) {
+		return domain.ErrAccountLocked
+	}
+	if now.Before(nextAttemptAt) {
+		return domain.ErrTooManyAttempts
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE login_attempts
+		SET next_attempt_at = $1, updated_at = now()
+		WHERE username = $2
+	`, now.Add(delay(failedAttempts+1)), username); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (repo *LoginAttemptRepository) RecordFailure(ctx context.Context, username string) (int, error) {
+	var attempts int
+	err := repo.db.QueryRow(ctx, `
+		INSERT INTO login_attempts (username, failed_attempts)
+		VALUES ($1, 1)
+		ON CONFLICT (username) DO UPDATE
+		SET failed_attempts = login_attempts.failed_attempts + 1, updated_at = now()
+		RETURNING failed_attempts
+	`, username).Scan(&attempts)
+	return attempts, err
+}
+
+func (repo *LoginAttemptRepository) Lock(ctx context.Context, username string, until time.Time) error {
+	_, err := repo.db.Exec(ctx, `
+		INSERT INTO login_attempts (username, locked_until)
+		VALUES ($1, $2)
+		ON CONFLICT (username) DO UPDATE
+		SET locked_until = $2, failed_attempts = 0, updated_at = now()
+	`, username, until)
+	return err
+}
+
+func (repo *LoginAttemptRepository) Reset(ctx context.Context, username string) error {
+	_, err := repo.db.Exec(ctx, `
+		DELETE FROM login_attempts WHERE username = $1
+	`, username)
+	return err
+}
+
+func (repo *LoginAttemptRepository) List(ctx context.Context) ([]domain.LoginAttempt, error) {
+	rows, err := repo.db.Query(ctx, `
+		SELECT username, failed_attempts, next_attempt_at, locked_until, updated_at
+		FROM login_attempts
+		ORDER BY updated_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []domain.LoginAttempt
+	for rows.Next() {
+		var a domain.LoginAttempt
+		if err := rows.Scan(
+			&a.Username,
+			&a.FailedAttempts,
+			&a.NextAttemptAt,
+			&a.LockedUntil,
+			&a.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+
+	return attempts, rows.Err()
+}
+
+func (repo *LoginAttemptRepository) Prune(ctx context.Context, before time.Time) (int64, error) {
+	cmd, err := repo.db.Exec(ctx, `
+		DELETE FROM login_attempts
+		WHERE updated_at < $1 AND (locked_until IS NULL OR locked_until < now())
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
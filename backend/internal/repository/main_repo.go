@@ -6,12 +6,24 @@ import (
 )
 
 type MainRepository struct {
-	HealthRepository domain.HealthRepository
-	UserRepository   domain.UserRepository
+	HealthRepository       domain.HealthRepository
+	UserRepository         domain.UserRepository
+	RoleRepository         domain.RoleRepository
+	APITokenRepository     domain.APITokenRepository
+	LoginAttemptRepository domain.LoginAttemptRepository
 }
 
 func NewMainRepository(db *pgxpool.Pool) *MainRepository {
 	healthRepo := NewHealthRepository(db)
 	userRepo := NewUserRepository(db)
-	return &MainRepository{HealthRepository: healthRepo, UserRepository: userRepo}
+	roleRepo := NewRoleRepository(db)
+	apiTokenRepo := NewAPITokenRepository(db)
+	loginAttemptRepo := NewLoginAttemptRepository(db)
+	return &MainRepository{
+		HealthRepository:       healthRepo,
+		UserRepository:         userRepo,
+		RoleRepository:         roleRepo,
+		APITokenRepository:     apiTokenRepo,
+		LoginAttemptRepository: loginAttemptRepo,
+	}
 }
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+type RoleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRoleRepository(db *pgxpool.Pool) domain.RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+func (repo *RoleRepository) List(ctx context.Context) ([]domain.Role, error) {
+	rows, err := repo.db.Query(ctx, `
+		SELECT r.name, COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role = r.name
+		GROUP BY r.name, r.created_at
+		ORDER BY r.created_at ASC, r.name ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []domain.Role
+	for rows.Next() {
+		var (
+			r           domain.Role
+			permissions []string
+		)
+		if err := rows.Scan(&r.Name, &permissions); err != nil {
+			return nil, err
+		}
+		r.Permissions = make([]domain.Permission, 0, len(permissions))
+		for _, p := range permissions {
+			r.Permissions = append(r.Permissions, domain.Permission(p))
+		}
+		roles = append(roles, r)
+	}
+
+	return roles, rows.Err()
+}
+
+func (repo *RoleRepository) Get(ctx context.Context, name string) (*domain.Role, error) {
+	rows, err := repo.db.Query(ctx, `
+		SELECT r.name, rp.permission
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role = r.name
+		WHERE r.name = $1
+		ORDER BY rp.permission
+	`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var role *domain.Role
+	for rows.Next() {
+		var (
+			roleName   string
+			permission *string
+		)
+		if err := rows.Scan(&roleName, &permission); err != nil {
+			return nil, err
+		}
+		if role == nil {
+			role = &domain.Role{Name: roleName, Permissions: []domain.Permission{}}
+		}
+		if permission != nil {
+			role.Permissions = append(role.Permissions, domain.Permission(*permission))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if role == nil {
+		return nil, domain.ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (repo *RoleRepository) SetPermissions(ctx context.Context, name string, permissions []domain.Permission) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM roles WHERE name = $1)
+	`, name).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrRoleNotFound
+	}
+
+	err = keepAdmin(ctx, tx, func() error {
+		if _, err := tx.Exec(ctx, `
+			DELETE FROM role_permissions WHERE role = $1
+		`, name); err != nil {
+			return err
+		}
+
+		for _, p := range permissions {
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO role_permissions (role, permission)
+				VALUES ($1, $2)
+				ON CONFLICT DO NOTHING
+			`, name, string(p)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
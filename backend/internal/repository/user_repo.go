@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nabidam/baaham/internal/domain"
 )
@@ -16,20 +19,49 @@ func NewUserRepository(db *pgxpool.Pool) domain.UserRepository {
 	return &UserRepository{db: db}
 }
 
-func (repo *UserRepository) Create(ctx context.Context, username string, passwordHash string, isAdmin bool) (*domain.User, error) {
+func (repo *UserRepository) Create(ctx context.Context, username string, passwordHash string, role string) (*domain.User, error) {
 	var u domain.User
 	err := repo.db.QueryRow(ctx, `
-		INSERT INTO users (username, password_hash, is_admin)
+		INSERT INTO users (username, password_hash, role)
 		VALUES ($1, $2, $3)
-		RETURNING id, username, password_hash, is_admin, created_at, updated_at
-	`, username, passwordHash, isAdmin).Scan(
+		RETURNING id, username, password_hash, role, is_bot, locked_until, created_at, updated_at
+	`, username, passwordHash, role).Scan(
 		&u.ID,
 		&u.Username,
 		&u.PasswordHash,
-		&u.IsAdmin,
+		&u.Role,
+		&u.IsBot,
+		&u.LockedUntil,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return nil, domain.ErrUserExists
+	}
+	return &u, err
+}
+
+func (repo *UserRepository) CreateBot(ctx context.Context, username string, role string) (*domain.User, error) {
+	var u domain.User
+	err := repo.db.QueryRow(ctx, `
+		INSERT INTO users (username, password_hash, role, is_bot)
+		VALUES ($1, '', $2, true)
+		RETURNING id, username, password_hash, role, is_bot, locked_until, created_at, updated_at
+	`, username, role).Scan(
+		&u.ID,
+		&u.Username,
+		&u.PasswordHash,
+		&u.Role,
+		&u.IsBot,
+		&u.LockedUntil,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return nil, domain.ErrUserExists
+	}
 	return &u, err
 }
 
@@ -45,15 +77,54 @@ func (repo *UserRepository) UpdatePassword(ctx context.Context, username string,
 	}
 
 	if cmd.RowsAffected() == 0 {
-		return fmt.Errorf("user not found")
+		return domain.ErrUserNotFound
 	}
 
 	return nil
 }
 
+func (repo *UserRepository) SetRole(ctx context.Context, username string, role string) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = keepAdmin(ctx, tx, func() error {
+		cmd, err := tx.Exec(ctx, `
+			UPDATE users
+			SET role = $1, updated_at = now()
+			WHERE username = $2
+		`, role, username)
+
+		if err != nil {
+			return err
+		}
+
+		if cmd.RowsAffected() == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (repo *UserRepository) CountBotsWithRole(ctx context.Context, role string) (int, error) {
+	var count int
+	err := repo.db.QueryRow(ctx, `
+		SELECT count(*) FROM users WHERE is_bot AND role = $1
+	`, role).Scan(&count)
+	return count, err
+}
+
 func (repo *UserRepository) List(ctx context.Context) ([]domain.User, error) {
 	rows, err := repo.db.Query(ctx, `
-		SELECT id, username, password_hash, is_admin, created_at, updated_at
+		SELECT id, username, password_hash, role, is_bot, locked_until, created_at, updated_at
 		FROM users
 		ORDER BY created_at ASC
 	`)
@@ -69,7 +140,9 @@ func (repo *UserRepository) List(ctx context.Context) ([]domain.User, error) {
 			&u.ID,
 			&u.Username,
 			&u.PasswordHash,
-			&u.IsAdmin,
+			&u.Role,
+			&u.IsBot,
+			&u.LockedUntil,
 			&u.CreatedAt,
 			&u.UpdatedAt,
 		); err != nil {
@@ -91,19 +164,60 @@ func (repo *UserRepository) Delete(ctx context.Context, username string) error {
 func (repo *UserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	var u domain.User
 	err := repo.db.QueryRow(ctx, `
-		SELECT id, username, password_hash, is_admin, created_at, updated_at
+		SELECT id, username, password_hash, role, is_bot, locked_until, created_at, updated_at
 		FROM users
 		WHERE username = $1
 	`, username).Scan(
 		&u.ID,
 		&u.Username,
 		&u.PasswordHash,
-		&u.IsAdmin,
+		&u.Role,
+		&u.IsBot,
+		&u.LockedUntil,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &u, nil
 }
+
+func (repo *UserRepository) Lock(ctx context.Context, username string, until time.Time) error {
+	cmd, err := repo.db.Exec(ctx, `
+		UPDATE users
+		SET locked_until = $1, updated_at = now()
+		WHERE username = $2
+	`, until, username)
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (repo *UserRepository) Unlock(ctx context.Context, username string) error {
+	cmd, err := repo.db.Exec(ctx, `
+		UPDATE users
+		SET locked_until = NULL, updated_at = now()
+		WHERE username = $1
+	`, username)
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
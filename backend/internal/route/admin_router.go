@@ -0,0 +1,14 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nabidam/baaham/internal/handler"
+)
+
+func RegisterAdminRoutes(api gin.IRoutes, h *handler.AdminHandler) {
+	api.GET("/users", h.ListUsers)
+	api.POST("/users", h.CreateUser)
+	api.PUT("/users/:username/role", h.SetUserRole)
+	api.GET("/roles", h.ListRoles)
+	api.PUT("/roles/:role/permissions", h.SetRolePermissions)
+}
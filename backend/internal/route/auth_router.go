@@ -1,11 +1,28 @@
 package route
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/nabidam/baaham/internal/config"
 	"github.com/nabidam/baaham/internal/handler"
+	"github.com/nabidam/baaham/internal/middleware"
+	"github.com/nabidam/baaham/pkg/ratelimit"
 )
 
-func RegisterAuthRoutes(api gin.IRoutes, h *handler.AuthHandler) {
-	api.POST("/login", h.Login)
+func RegisterAuthRoutes(api gin.IRoutes, h *handler.AuthHandler, cfg *config.Config, auth gin.HandlerFunc) {
+	loginHandlers := []gin.HandlerFunc{}
+	// a limit of 0 disables login rate limiting
+	if cfg.Auth.LoginRateLimit > 0 {
+		ipLimiter := ratelimit.New(cfg.Auth.LoginRateLimit, time.Minute)
+		usernameLimiter := ratelimit.New(cfg.Auth.LoginRateLimit, time.Minute)
+		loginHandlers = append(loginHandlers,
+			middleware.RateLimit(ipLimiter, middleware.ClientIPKey),
+			middleware.RateLimit(usernameLimiter, middleware.LoginUsernameKey),
+		)
+	}
+
+	api.POST("/login", append(loginHandlers, h.Login)...)
+	api.GET("/me", auth, h.Me)
 	// api.POST("/register", h.Register)
 }
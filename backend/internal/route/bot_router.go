@@ -0,0 +1,13 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nabidam/baaham/internal/handler"
+)
+
+func RegisterBotRoutes(api gin.IRoutes, h *handler.BotHandler) {
+	api.POST("", h.CreateBot)
+	api.GET("/:username/tokens", h.ListTokens)
+	api.POST("/:username/tokens", h.CreateToken)
+	api.DELETE("/:username/tokens/:id", h.RevokeToken)
+}
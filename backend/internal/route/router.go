@@ -2,10 +2,13 @@ package route
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/nabidam/baaham/internal/config"
+	"github.com/nabidam/baaham/internal/domain"
 	"github.com/nabidam/baaham/internal/handler"
+	"github.com/nabidam/baaham/internal/middleware"
 )
 
-func RegisterRoutes(r *gin.Engine, h *handler.MainHandler) {
+func RegisterRoutes(r *gin.Engine, h *handler.MainHandler, auth gin.HandlerFunc, cfg *config.Config) {
 	// Define routes
 	api := r.Group("/api/v1")
 	{
@@ -15,7 +18,22 @@ func RegisterRoutes(r *gin.Engine, h *handler.MainHandler) {
 		// Auth routes
 		authGroup := api.Group("/auth")
 		{
-			RegisterAuthRoutes(authGroup, h.AuthHandler)
+			RegisterAuthRoutes(authGroup, h.AuthHandler, cfg, auth)
+		}
+
+		// Admin routes
+		adminGroup := api.Group("/admin")
+		adminGroup.Use(
+			auth,
+			middleware.RequirePermission(domain.PermManageUsers),
+		)
+		{
+			RegisterAdminRoutes(adminGroup, h.AdminHandler)
+
+			botGroup := adminGroup.Group("/bots")
+			{
+				RegisterBotRoutes(botGroup, h.BotHandler)
+			}
 		}
 
 	}
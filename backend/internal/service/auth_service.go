@@ -3,39 +3,180 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/nabidam/baaham/internal/domain"
+	"github.com/nabidam/baaham/pkg/apitoken"
 	"github.com/nabidam/baaham/pkg/jwt"
 	pass "github.com/nabidam/baaham/pkg/password"
 )
 
+const (
+	// freeLoginAttempts failed logins are allowed before delays kick in.
+	freeLoginAttempts = 3
+	maxLoginDelay     = time.Minute
+)
+
 type AuthService struct {
-	repo      domain.UserRepository
-	jwtSecret string
+	repo            domain.UserRepository
+	roleRepo        domain.RoleRepository
+	tokenRepo       domain.APITokenRepository
+	attemptRepo     domain.LoginAttemptRepository
+	jwtSecret       string
+	maxFailedLogins int
+	lockoutDuration time.Duration
+	loginDelayBase  time.Duration
 }
 
-func NewAuthService(r domain.UserRepository, jwtSecret string) domain.AuthService {
-	return &AuthService{repo: r, jwtSecret: jwtSecret}
+func NewAuthService(r domain.UserRepository, roleRepo domain.RoleRepository, tokenRepo domain.APITokenRepository, attemptRepo domain.LoginAttemptRepository, jwtSecret string, maxFailedLogins int, lockoutDuration time.Duration, loginDelayBase time.Duration) domain.AuthService {
+	// hash the dummy password up front so the first unknown-user login is
+	// not slower than the rest
+	pass.CheckDummy("")
+
+	return &AuthService{
+		repo:            r,
+		roleRepo:        roleRepo,
+		tokenRepo:       tokenRepo,
+		attemptRepo:     attemptRepo,
+		jwtSecret:       jwtSecret,
+		maxFailedLogins: maxFailedLogins,
+		lockoutDuration: lockoutDuration,
+		loginDelayBase:  loginDelayBase,
+	}
 }
 
+// Login treats unknown usernames, bots and admin-locked accounts exactly like
+// a wrong password: the same DB round trips, the same bcrypt cost and the same
+// throttling, so neither the status code nor the timing reveals whether the
+// username exists.
 func (s *AuthService) Login(ctx context.Context, username string, password string) (*domain.LoginResponse, error) {
+	attemptKey := strings.ToLower(username)
+
 	// Get user by username
 	user, err := s.repo.GetByUsername(ctx, username)
-	if err != nil {
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if err := s.attemptRepo.Begin(ctx, attemptKey, s.loginDelay); err != nil {
+		pass.CheckDummy(password)
 		return nil, err
 	}
 
-	// compare passwords
-	isPasswordCorrect := pass.CheckPasswordHash(password, user.PasswordHash)
+	// compare passwords. Bots have no password and must use API tokens.
+	isPasswordCorrect := false
+	if user == nil || user.IsBot || user.IsLocked(time.Now()) {
+		pass.CheckDummy(password)
+	} else {
+		isPasswordCorrect = pass.CheckPasswordHash(password, user.PasswordHash)
+	}
+
 	if !isPasswordCorrect {
-		return nil, errors.New("invalid credentials")
+		if err := s.recordFailedLogin(ctx, attemptKey); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if err := s.attemptRepo.Reset(ctx, attemptKey); err != nil {
+		return nil, err
 	}
 
 	// generate JWT token
-	token, err := jwt.GenerateToken(user.ID, user.Username, user.IsAdmin, []byte(s.jwtSecret), 24*time.Hour)
+	token, err := jwt.GenerateToken(user.ID, user.Username, []byte(s.jwtSecret), 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
 
 	return &domain.LoginResponse{
 		Token: token,
 	}, nil
 }
+
+// Authenticate resolves the token to a user, then reads that user's current
+// role and permissions. Role changes and locks therefore apply on the next
+// request, not when the token expires.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*domain.Identity, error) {
+	user, err := s.authenticateUser(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.Get(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Identity{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Role:        role.Name,
+		Permissions: role.Permissions,
+		Bot:         user.IsBot,
+	}, nil
+}
+
+func (s *AuthService) authenticateUser(ctx context.Context, token string) (*domain.User, error) {
+	if apitoken.IsToken(token) {
+		user, err := s.tokenRepo.Authenticate(ctx, apitoken.Hash(token))
+		if errors.Is(err, domain.ErrAPITokenNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		if err != nil {
+			return nil, err
+		}
+		if user.IsLocked(time.Now()) {
+			return nil, domain.ErrInvalidToken
+		}
+		return user, nil
+	}
+
+	claims, err := jwt.ParseToken(token, []byte(s.jwtSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByUsername(ctx, claims.Username)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// a deleted and recreated username must not inherit old tokens
+	if user.ID != claims.UserID || user.IsLocked(time.Now()) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	return user, nil
+}
+
+func (s *AuthService) recordFailedLogin(ctx context.Context, attemptKey string) error {
+	attempts, err := s.attemptRepo.RecordFailure(ctx, attemptKey)
+	if err != nil {
+		return err
+	}
+
+	if s.maxFailedLogins > 0 && attempts >= s.maxFailedLogins {
+		return s.attemptRepo.Lock(ctx, attemptKey, time.Now().Add(s.lockoutDuration))
+	}
+
+	return nil
+}
+
+// loginDelay is how long a username must wait after its last failed login
+// before the next attempt is checked. It doubles with every failure past
+// freeLoginAttempts.
+func (s *AuthService) loginDelay(failedAttempts int) time.Duration {
+	if failedAttempts < freeLoginAttempts {
+		return 0
+	}
+
+	delay := s.loginDelayBase
+	for i := freeLoginAttempts; i < failedAttempts && delay < maxLoginDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxLoginDelay)
+}
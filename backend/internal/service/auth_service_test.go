@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nabidam/baaham/internal/domain"
+	"github.com/nabidam/baaham/pkg/apitoken"
+	"github.com/nabidam/baaham/pkg/jwt"
+	pass "github.com/nabidam/baaham/pkg/password"
+)
+
+// fakeAttemptRepo mirrors LoginAttemptRepository in memory, keyed like the
+// real table by the lowercased username.
+type fakeAttemptRepo struct {
+	rows map[string]*fakeAttempt
+}
+
+type fakeAttempt struct {
+	failed      int
+	next        time.Time
+	lockedUntil time.Time
+}
+
+func (r *fakeAttemptRepo) row(username string) *fakeAttempt {
+	a, ok := r.rows[username]
+	if !ok {
+		a = &fakeAttempt{}
+		r.rows[username] = a
+	}
+	return a
+}
+
+func (r *fakeAttemptRepo) Begin(ctx context.Context, username string, delay func(int) time.Duration) error {
+	a := r.row(username)
+	now := time.Now()
+	if now.Before(a.lockedUntil) {
+		return domain.ErrAccountLocked
+	}
+	if now.Before(a.next) {
+		return domain.ErrTooManyAttempts
+	}
+	a.next = now.Add(delay(a.failed + 1))
+	return nil
+}
+
+func (r *fakeAttemptRepo) RecordFailure(ctx context.Context, username string) (int, error) {
+	a := r.row(username)
+	a.failed++
+	return a.failed, nil
+}
+
+func (r *fakeAttemptRepo) Lock(ctx context.Context, username string, until time.Time) error {
+	a := r.row(username)
+	a.lockedUntil = until
+	a.failed = 0
+	return nil
+}
+
+func (r *fakeAttemptRepo) Reset(ctx context.Context, username string) error {
+	delete(r.rows, username)
+	return nil
+}
+
+func (r *fakeAttemptRepo) List(ctx context.Context) ([]domain.LoginAttempt, error) {
+	return nil, nil
+}
+
+func (r *fakeAttemptRepo) Prune(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// fakeTokenRepo maps token hashes to their owners. Like the real query it
+// hides expired tokens, but it does not filter locked users, so the
+// service's own lock check is exercised.
+type fakeTokenRepo struct {
+	domain.APITokenRepository
+	tokens map[string]fakeToken
+}
+
+type fakeToken struct {
+	user      *domain.User
+	expiresAt *time.Time
+}
+
+func (r *fakeTokenRepo) Authenticate(ctx context.Context, tokenHash string) (*domain.User, error) {
+	t, ok := r.tokens[tokenHash]
+	if !ok || (t.expiresAt != nil && !time.Now().Before(*t.expiresAt)) {
+		return nil, domain.ErrAPITokenNotFound
+	}
+	return t.user, nil
+}
+
+const (
+	testJWTSecret = "test-secret"
+	testPassword  = "correct horse"
+)
+
+// newAuthTest returns an AuthService over fake repos holding a member
+// "nabi", an admin-locked member "sara" and a bot "dj". nabi and sara both
+// use testPassword.
+func newAuthTest(t *testing.T, maxFailedLogins int, loginDelayBase time.Duration) (*AuthService, *fakeUserRepo, *fakeAttemptRepo) {
+	t.Helper()
+
+	hash, err := pass.HashPassword(testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lockedUntil := time.Now().Add(time.Hour)
+	users, roles := newFakeRepos(
+		&domain.User{ID: "u1", Username: "nabi", PasswordHash: hash, Role: domain.RoleMember},
+		&domain.User{ID: "u2", Username: "sara", PasswordHash: hash, Role: domain.RoleMember, LockedUntil: &lockedUntil},
+		&domain.User{ID: "u3", Username: "dj", Role: domain.RoleBot, IsBot: true},
+	)
+	attempts := &fakeAttemptRepo{rows: map[string]*fakeAttempt{}}
+
+	svc := NewAuthService(users, roles, nil, attempts, testJWTSecret, maxFailedLogins, time.Hour, loginDelayBase)
+	return svc.(*AuthService), users, attempts
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantErr  error
+	}{
+		{"correct password", "nabi", testPassword, nil},
+		{"wrong password", "nabi", "wrong", domain.ErrInvalidCredentials},
+		{"unknown username", "ghost", testPassword, domain.ErrInvalidCredentials},
+		{"bot", "dj", "", domain.ErrInvalidCredentials},
+		{"admin-locked with correct password", "sara", testPassword, domain.ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		svc, _, attempts := newAuthTest(t, 10, 0)
+
+		resp, err := svc.Login(context.Background(), tt.username, tt.password)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+			continue
+		}
+
+		if tt.wantErr == nil {
+			if resp == nil || resp.Token == "" {
+				t.Errorf("%s: expected a token", tt.name)
+			}
+			continue
+		}
+		// every failure counts, whether or not the user exists
+		if a := attempts.rows[tt.username]; a == nil || a.failed != 1 {
+			t.Errorf("%s: expected one recorded failure, got %+v", tt.name, a)
+		}
+	}
+}
+
+func TestLoginLocksOutAfterMaxFailedLogins(t *testing.T) {
+	for _, username := range []string{"nabi", "ghost"} {
+		svc, _, _ := newAuthTest(t, 3, 0)
+		ctx := context.Background()
+
+		for i := range 3 {
+			if _, err := svc.Login(ctx, username, "wrong"); !errors.Is(err, domain.ErrInvalidCredentials) {
+				t.Fatalf("%s attempt %d: expected ErrInvalidCredentials, got %v", username, i+1, err)
+			}
+		}
+
+		// locked now, even with the right password
+		if _, err := svc.Login(ctx, username, testPassword); !errors.Is(err, domain.ErrAccountLocked) {
+			t.Fatalf("%s: expected ErrAccountLocked, got %v", username, err)
+		}
+	}
+}
+
+func TestLoginThrottlesUnknownUsernamesLikeRealOnes(t *testing.T) {
+	for _, username := range []string{"nabi", "ghost"} {
+		svc, _, _ := newAuthTest(t, 100, time.Hour)
+		ctx := context.Background()
+
+		for i := range freeLoginAttempts {
+			if _, err := svc.Login(ctx, username, "wrong"); !errors.Is(err, domain.ErrInvalidCredentials) {
+				t.Fatalf("%s attempt %d: expected ErrInvalidCredentials, got %v", username, i+1, err)
+			}
+		}
+
+		if _, err := svc.Login(ctx, username, "wrong"); !errors.Is(err, domain.ErrTooManyAttempts) {
+			t.Fatalf("%s: expected ErrTooManyAttempts, got %v", username, err)
+		}
+	}
+}
+
+func TestLoginKeysAttemptsByLowercasedUsername(t *testing.T) {
+	svc, _, attempts := newAuthTest(t, 10, 0)
+
+	if _, err := svc.Login(context.Background(), "Ghost", "wrong"); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+	if a := attempts.rows["ghost"]; a == nil || a.failed != 1 {
+		t.Fatalf("expected failure under lowercased key, got %+v", attempts.rows)
+	}
+}
+
+func TestLoginSuccessResetsFailures(t *testing.T) {
+	svc, _, attempts := newAuthTest(t, 10, 0)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := svc.Login(ctx, "nabi", "wrong"); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+		}
+	}
+	if _, err := svc.Login(ctx, "nabi", testPassword); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := attempts.rows["nabi"]; ok {
+		t.Fatal("expected failures to be reset after a successful login")
+	}
+}
+
+func TestLoginDelay(t *testing.T) {
+	s := &AuthService{loginDelayBase: time.Second}
+
+	tests := []struct {
+		failedAttempts int
+		want           time.Duration
+	}{
+		{0, 0},
+		{1, 0},
+		{freeLoginAttempts - 1, 0},
+		{freeLoginAttempts, time.Second},
+		{freeLoginAttempts + 1, 2 * time.Second},
+		{freeLoginAttempts + 2, 4 * time.Second},
+		{freeLoginAttempts + 5, 32 * time.Second},
+		{freeLoginAttempts + 6, maxLoginDelay},
+		{freeLoginAttempts + 1000, maxLoginDelay},
+	}
+
+	for _, tt := range tests {
+		if got := s.loginDelay(tt.failedAttempts); got != tt.want {
+			t.Errorf("loginDelay(%d) = %s, want %s", tt.failedAttempts, got, tt.want)
+		}
+	}
+}
+
+func TestLoginDelayZeroBase(t *testing.T) {
+	s := &AuthService{}
+
+	if got := s.loginDelay(freeLoginAttempts + 10); got != 0 {
+		t.Errorf("loginDelay with zero base = %s, want 0", got)
+	}
+}
+
+func TestLoginDelayBaseAboveCap(t *testing.T) {
+	s := &AuthService{loginDelayBase: 2 * maxLoginDelay}
+
+	if got := s.loginDelay(freeLoginAttempts); got != maxLoginDelay {
+		t.Errorf("loginDelay = %s, want cap %s", got, maxLoginDelay)
+	}
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	svc, users, _ := newAuthTest(t, 10, 0)
+
+	token := func(userID string, username string, secret string) string {
+		t.Helper()
+		tok, err := jwt.GenerateToken(userID, username, []byte(secret), time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+
+	identity, err := svc.Authenticate(context.Background(), token("u1", "nabi", testJWTSecret))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identity.UserID != "u1" || identity.Role != domain.RoleMember || !identity.Can(domain.PermJoinRooms) || identity.Bot {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	// "ghost" was deleted and recreated as u9; its old token still says u8
+	users.users["ghost"] = &domain.User{ID: "u9", Username: "ghost", Role: domain.RoleMember}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"locked user", token("u2", "sara", testJWTSecret)},
+		{"recreated username", token("u8", "ghost", testJWTSecret)},
+		{"deleted user", token("u7", "gone", testJWTSecret)},
+		{"wrong secret", token("u1", "nabi", "other-secret")},
+		{"garbage", "not-a-jwt"},
+	}
+
+	for _, tt := range tests {
+		if _, err := svc.Authenticate(context.Background(), tt.token); !errors.Is(err, domain.ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestAuthenticateAPIToken(t *testing.T) {
+	svc, users, _ := newAuthTest(t, 10, 0)
+
+	expired := time.Now().Add(-time.Minute)
+	lockedUntil := time.Now().Add(time.Hour)
+	lockedBot := &domain.User{ID: "u4", Username: "dj2", Role: domain.RoleBot, IsBot: true, LockedUntil: &lockedUntil}
+	svc.tokenRepo = &fakeTokenRepo{tokens: map[string]fakeToken{
+		apitoken.Hash("bht_valid"):   {user: users.users["dj"]},
+		apitoken.Hash("bht_expired"): {user: users.users["dj"], expiresAt: &expired},
+		apitoken.Hash("bht_locked"):  {user: lockedBot},
+	}}
+
+	identity, err := svc.Authenticate(context.Background(), "bht_valid")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identity.Username != "dj" || !identity.Bot || identity.Role != domain.RoleBot {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	for _, token := range []string{"bht_unknown", "bht_expired", "bht_locked"} {
+		if _, err := svc.Authenticate(context.Background(), token); !errors.Is(err, domain.ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", token, err)
+		}
+	}
+}
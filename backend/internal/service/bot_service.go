@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/nabidam/baaham/internal/domain"
+	"github.com/nabidam/baaham/pkg/apitoken"
+)
+
+type BotService struct {
+	userRepo  domain.UserRepository
+	roleRepo  domain.RoleRepository
+	tokenRepo domain.APITokenRepository
+}
+
+func NewBotService(userRepo domain.UserRepository, roleRepo domain.RoleRepository, tokenRepo domain.APITokenRepository) domain.BotService {
+	return &BotService{userRepo: userRepo, roleRepo: roleRepo, tokenRepo: tokenRepo}
+}
+
+func (s *BotService) Create(ctx context.Context, username string, role string) (*domain.User, error) {
+	if role == "" {
+		role = domain.RoleBot
+	}
+	r, err := s.roleRepo.Get(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(r.Permissions, domain.PermManageUsers) {
+		return nil, domain.ErrBotRoleNotAllowed
+	}
+
+	return s.userRepo.CreateBot(ctx, username, role)
+}
+
+func (s *BotService) CreateToken(ctx context.Context, username string, name string, ttl time.Duration) (*domain.CreatedAPIToken, error) {
+	bot, err := s.getBot(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	token, hash, err := apitoken.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if ttl > 0 {
+		t := time.Now().Add(ttl)
+		expiresAt = &t
+	}
+
+	created, err := s.tokenRepo.Create(ctx, bot.ID, name, hash, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CreatedAPIToken{APIToken: *created, Token: token}, nil
+}
+
+func (s *BotService) ListTokens(ctx context.Context, username string) ([]domain.APIToken, error) {
+	bot, err := s.getBot(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tokenRepo.ListByUser(ctx, bot.ID)
+}
+
+func (s *BotService) RevokeToken(ctx context.Context, username string, id string) error {
+	if !domain.IsUUID(id) {
+		return domain.ErrAPITokenNotFound
+	}
+
+	bot, err := s.getBot(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return s.tokenRepo.Delete(ctx, bot.ID, id)
+}
+
+func (s *BotService) getBot(ctx context.Context, username string) (*domain.User, error) {
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsBot {
+		return nil, domain.ErrNotBot
+	}
+	return user, nil
+}
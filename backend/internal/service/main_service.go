@@ -9,11 +9,33 @@ import (
 type MainService struct {
 	HealthService domain.HealthService
 	AuthService   domain.AuthService
+	UserService   domain.UserService
+	RoleService   domain.RoleService
+	BotService    domain.BotService
 }
 
 func NewMainService(repo *repository.MainRepository, cfg *config.Config) *MainService {
 	healthSvc := NewHealthService(repo.HealthRepository)
-	authSvc := NewAuthService(repo.UserRepository, cfg.JWTSecret)
+	authSvc := NewAuthService(
+		repo.UserRepository,
+		repo.RoleRepository,
+		repo.APITokenRepository,
+		repo.LoginAttemptRepository,
+		cfg.JWTSecret,
+		cfg.Auth.MaxFailedLogins,
+		cfg.Auth.LockoutDuration,
+		cfg.Auth.LoginDelayBase,
+	)
 
-	return &MainService{HealthService: healthSvc, AuthService: authSvc}
+	userSvc := NewUserService(repo.UserRepository, repo.RoleRepository)
+	roleSvc := NewRoleService(repo.RoleRepository, repo.UserRepository)
+	botSvc := NewBotService(repo.UserRepository, repo.RoleRepository, repo.APITokenRepository)
+
+	return &MainService{
+		HealthService: healthSvc,
+		AuthService:   authSvc,
+		UserService:   userSvc,
+		RoleService:   roleSvc,
+		BotService:    botSvc,
+	}
 }
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+type RoleService struct {
+	repo     domain.RoleRepository
+	userRepo domain.UserRepository
+}
+
+func NewRoleService(r domain.RoleRepository, userRepo domain.UserRepository) domain.RoleService {
+	return &RoleService{repo: r, userRepo: userRepo}
+}
+
+func (s *RoleService) List(ctx context.Context) ([]domain.Role, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *RoleService) SetPermissions(ctx context.Context, name string, permissions []string) (*domain.Role, error) {
+	perms := make([]domain.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		perm, err := domain.ParsePermission(p)
+		if err != nil {
+			return nil, err
+		}
+		perms = append(perms, perm)
+	}
+
+	if slices.Contains(perms, domain.PermManageUsers) {
+		bots, err := s.userRepo.CountBotsWithRole(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if bots > 0 {
+			return nil, domain.ErrBotRoleNotAllowed
+		}
+	}
+
+	if err := s.repo.SetPermissions(ctx, name, perms); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Get(ctx, name)
+}
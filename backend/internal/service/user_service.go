@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nabidam/baaham/internal/domain"
+	pass "github.com/nabidam/baaham/pkg/password"
+)
+
+type UserService struct {
+	repo     domain.UserRepository
+	roleRepo domain.RoleRepository
+}
+
+func NewUserService(r domain.UserRepository, roleRepo domain.RoleRepository) domain.UserService {
+	return &UserService{repo: r, roleRepo: roleRepo}
+}
+
+func (s *UserService) Create(ctx context.Context, username string, password string, role string) (*domain.User, error) {
+	if role == "" {
+		role = domain.RoleMember
+	}
+	if _, err := s.roleRepo.Get(ctx, role); err != nil {
+		return nil, err
+	}
+
+	hash, err := pass.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, username, hash, role)
+}
+
+func (s *UserService) List(ctx context.Context) ([]domain.User, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *UserService) SetRole(ctx context.Context, username string, role string) error {
+	newRole, err := s.roleRepo.Get(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if user.IsBot && slices.Contains(newRole.Permissions, domain.PermManageUsers) {
+		return domain.ErrBotRoleNotAllowed
+	}
+
+	return s.repo.SetRole(ctx, username, role)
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nabidam/baaham/internal/domain"
+)
+
+// fakeUserRepo implements the parts of domain.UserRepository the services
+// use; calling anything else panics on the nil embedded interface.
+type fakeUserRepo struct {
+	domain.UserRepository
+	users map[string]*domain.User
+	roles *fakeRoleRepo
+}
+
+func (r *fakeUserRepo) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	u, ok := r.users[username]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return u, nil
+}
+
+// SetRole enforces the last-admin rule the way the real repository does.
+func (r *fakeUserRepo) SetRole(ctx context.Context, username string, role string) error {
+	u, ok := r.users[username]
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+
+	before := r.countAdmins()
+	old := u.Role
+	u.Role = role
+	if before > 0 && r.countAdmins() == 0 {
+		u.Role = old
+		return domain.ErrLastAdmin
+	}
+	return nil
+}
+
+// countAdmins counts unlocked non-bot users with manage_users.
+func (r *fakeUserRepo) countAdmins() int {
+	count := 0
+	now := time.Now()
+	for _, u := range r.users {
+		if u.IsBot || u.IsLocked(now) {
+			continue
+		}
+		if slices.Contains(r.roles.roles[u.Role], domain.PermManageUsers) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *fakeUserRepo) CountBotsWithRole(ctx context.Context, role string) (int, error) {
+	count := 0
+	for _, u := range r.users {
+		if u.IsBot && u.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type fakeRoleRepo struct {
+	roles map[string][]domain.Permission
+	users *fakeUserRepo
+}
+
+func (r *fakeRoleRepo) List(ctx context.Context) ([]domain.Role, error) {
+	return nil, nil
+}
+
+func (r *fakeRoleRepo) Get(ctx context.Context, name string) (*domain.Role, error) {
+	perms, ok := r.roles[name]
+	if !ok {
+		return nil, domain.ErrRoleNotFound
+	}
+	return &domain.Role{Name: name, Permissions: slices.Clone(perms)}, nil
+}
+
+// SetPermissions enforces the last-admin rule the way the real repository
+// does.
+func (r *fakeRoleRepo) SetPermissions(ctx context.Context, name string, permissions []domain.Permission) error {
+	old, ok := r.roles[name]
+	if !ok {
+		return domain.ErrRoleNotFound
+	}
+
+	before := r.users.countAdmins()
+	r.roles[name] = permissions
+	if before > 0 && r.users.countAdmins() == 0 {
+		r.roles[name] = old
+		return domain.ErrLastAdmin
+	}
+	return nil
+}
+
+func newFakeRepos(users ...*domain.User) (*fakeUserRepo, *fakeRoleRepo) {
+	roles := &fakeRoleRepo{roles: map[string][]domain.Permission{
+		domain.RoleAdmin:  {domain.PermManageUsers, domain.PermJoinRooms},
+		domain.RoleMember: {domain.PermJoinRooms},
+		domain.RoleBot:    {domain.PermJoinRooms},
+	}}
+	userRepo := &fakeUserRepo{users: map[string]*domain.User{}, roles: roles}
+	roles.users = userRepo
+	for _, u := range users {
+		userRepo.users[u.Username] = u
+	}
+	return userRepo, roles
+}
+
+func TestSetRoleRejectsDemotingLastAdmin(t *testing.T) {
+	users, roles := newFakeRepos(
+		&domain.User{Username: "nabi", Role: domain.RoleAdmin},
+		&domain.User{Username: "sara", Role: domain.RoleMember},
+	)
+	svc := NewUserService(users, roles)
+
+	err := svc.SetRole(context.Background(), "nabi", domain.RoleMember)
+	if !errors.Is(err, domain.ErrLastAdmin) {
+		t.Fatalf("expected ErrLastAdmin, got %v", err)
+	}
+	if users.users["nabi"].Role != domain.RoleAdmin {
+		t.Fatal("role changed despite error")
+	}
+}
+
+func TestSetRoleAllowsDemotingWithAnotherAdmin(t *testing.T) {
+	users, roles := newFakeRepos(
+		&domain.User{Username: "nabi", Role: domain.RoleAdmin},
+		&domain.User{Username: "sara", Role: domain.RoleAdmin},
+	)
+	svc := NewUserService(users, roles)
+
+	if err := svc.SetRole(context.Background(), "nabi", domain.RoleMember); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if users.users["nabi"].Role != domain.RoleMember {
+		t.Fatal("role not changed")
+	}
+}
+
+func TestSetRoleIgnoresLockedAdmins(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Hour)
+	users, roles := newFakeRepos(
+		&domain.User{Username: "nabi", Role: domain.RoleAdmin},
+		&domain.User{Username: "sara", Role: domain.RoleAdmin, LockedUntil: &lockedUntil},
+	)
+	svc := NewUserService(users, roles)
+
+	err := svc.SetRole(context.Background(), "nabi", domain.RoleMember)
+	if !errors.Is(err, domain.ErrLastAdmin) {
+		t.Fatalf("expected ErrLastAdmin, got %v", err)
+	}
+}
+
+func TestSetRoleAllowsPromotionWithoutAdmins(t *testing.T) {
+	users, roles := newFakeRepos(
+		&domain.User{Username: "sara", Role: domain.RoleMember},
+	)
+	svc := NewUserService(users, roles)
+
+	if err := svc.SetRole(context.Background(), "sara", domain.RoleAdmin); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSetPermissionsRejectsRemovingLastManageUsers(t *testing.T) {
+	users, roles := newFakeRepos(
+		&domain.User{Username: "nabi", Role: domain.RoleAdmin},
+	)
+	svc := NewRoleService(roles, users)
+
+	_, err := svc.SetPermissions(context.Background(), domain.RoleAdmin, []string{"join_rooms"})
+	if !errors.Is(err, domain.ErrLastAdmin) {
+		t.Fatalf("expected ErrLastAdmin, got %v", err)
+	}
+}
+
+func TestSetPermissionsAllowsRemovingWhenAnotherRoleManagesUsers(t *testing.T) {
+	users, roles := newFakeRepos(
+		&domain.User{Username: "nabi", Role: domain.RoleAdmin},
+		&domain.User{Username: "sara", Role: domain.RoleModerator},
+	)
+	roles.roles[domain.RoleModerator] = []domain.Permission{domain.PermManageUsers}
+	svc := NewRoleService(roles, users)
+
+	if _, err := svc.SetPermissions(context.Background(), domain.RoleAdmin, []string{"join_rooms"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSetRoleRejectsAdminBot(t *testing.T) {
+	users, roles := newFakeRepos(
+		&domain.User{Username: "nabi", Role: domain.RoleAdmin},
+		&domain.User{Username: "dj", Role: domain.RoleBot, IsBot: true},
+	)
+	svc := NewUserService(users, roles)
+
+	err := svc.SetRole(context.Background(), "dj", domain.RoleAdmin)
+	if !errors.Is(err, domain.ErrBotRoleNotAllowed) {
+		t.Fatalf("expected ErrBotRoleNotAllowed, got %v", err)
+	}
+}
+
+func TestSetPermissionsRejectsManageUsersForBotRole(t *testing.T) {
+	users, roles := newFakeRepos(
+		&domain.User{Username: "dj", Role: domain.RoleBot, IsBot: true},
+	)
+	svc := NewRoleService(roles, users)
+
+	_, err := svc.SetPermissions(context.Background(), domain.RoleBot, []string{"join_rooms", "manage_users"})
+	if !errors.Is(err, domain.ErrBotRoleNotAllowed) {
+		t.Fatalf("expected ErrBotRoleNotAllowed, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Set by admins through usercli; blocks both login and API tokens.
+ALTER TABLE users ADD COLUMN locked_until TIMESTAMPTZ;
+
+-- Keyed by the lowercased username as typed at login, whether or not such
+-- a user exists, so unknown usernames are throttled exactly like real ones.
+CREATE TABLE login_attempts (
+    username TEXT PRIMARY KEY,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_login_attempts_updated_at ON login_attempts(updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS login_attempts;
+
+ALTER TABLE users DROP COLUMN IF EXISTS locked_until;
+-- +goose StatementEnd
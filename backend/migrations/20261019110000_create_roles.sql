@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE roles (
+    name TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE role_permissions (
+    role TEXT NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission TEXT NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles (name) VALUES ('admin'), ('moderator'), ('member'), ('guest');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'manage_users'),
+    ('admin', 'manage_rooms'),
+    ('admin', 'manage_media'),
+    ('admin', 'upload'),
+    ('admin', 'moderate_chat'),
+    ('admin', 'join_rooms'),
+    ('moderator', 'manage_media'),
+    ('moderator', 'upload'),
+    ('moderator', 'moderate_chat'),
+    ('moderator', 'join_rooms'),
+    ('member', 'upload'),
+    ('member', 'join_rooms'),
+    ('guest', 'join_rooms');
+
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'member' REFERENCES roles(name);
+UPDATE users SET role = 'admin' WHERE is_admin;
+ALTER TABLE users DROP COLUMN is_admin;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT false;
+UPDATE users SET is_admin = true WHERE role = 'admin';
+ALTER TABLE users DROP COLUMN role;
+
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
+-- +goose StatementEnd
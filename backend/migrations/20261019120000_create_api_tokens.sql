@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN is_bot BOOLEAN NOT NULL DEFAULT false;
+
+INSERT INTO roles (name) VALUES ('bot');
+INSERT INTO role_permissions (role, permission) VALUES ('bot', 'join_rooms');
+
+CREATE TABLE api_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    last_used_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_api_tokens_user_id ON api_tokens(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_tokens;
+
+UPDATE users SET role = 'member' WHERE role = 'bot';
+DELETE FROM roles WHERE name = 'bot';
+
+ALTER TABLE users DROP COLUMN is_bot;
+-- +goose StatementEnd
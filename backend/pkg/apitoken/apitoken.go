@@ -0,0 +1,35 @@
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Prefix marks API tokens so they can be told apart from JWTs.
+const Prefix = "bht_"
+
+// Generate returns a new random token and the hash to store for it. The
+// plain token is only ever shown once.
+func Generate() (token string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token = Prefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, Hash(token), nil
+}
+
+// Hash is a plain SHA-256: tokens are long and random, so a slow hash
+// would only add cost to every authenticated request.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsToken(s string) bool {
+	return strings.HasPrefix(s, Prefix)
+}
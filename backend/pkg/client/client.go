@@ -0,0 +1,138 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Client talks to the baaham HTTP API. It authenticates either with a bot
+// API token (WithToken) or by logging in with a username and password.
+type Client struct {
+	baseURL string
+	http    *http.Client
+	token   string
+}
+
+type Option func(*Client)
+
+// WithToken sets the bearer token, typically a bot API token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// New creates a client for a server, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimRight(baseURL, "/") + "/api/v1",
+		http:    http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Identity is the user or bot behind the client's token.
+type Identity struct {
+	UserID      string   `json:"user_id"`
+	Username    string   `json:"username"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	Bot         bool     `json:"bot"`
+}
+
+// Error is returned for non-2xx responses.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("baaham: %d %s", e.StatusCode, e.Message)
+}
+
+// Login exchanges a username and password for a token and uses it for
+// subsequent requests.
+func (c *Client) Login(ctx context.Context, username string, password string) error {
+	req := map[string]string{"username": username, "password": password}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/auth/login", req, &resp); err != nil {
+		return err
+	}
+
+	c.token = resp.Token
+	return nil
+}
+
+// Me returns the identity of the authenticated user or bot.
+func (c *Client) Me(ctx context.Context) (*Identity, error) {
+	var id Identity
+	if err := c.do(ctx, http.MethodGet, "/auth/me", nil, &id); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (c *Client) Health(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/health", nil, nil)
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, body any, out any) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var e struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		if e.Error == "" {
+			e.Error = http.StatusText(resp.StatusCode)
+		}
+		return &Error{StatusCode: resp.StatusCode, Message: e.Error}
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
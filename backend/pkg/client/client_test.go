@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid username or password"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": "jwt-" + req.Username})
+	})
+	mux.HandleFunc("GET /api/v1/auth/me", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer jwt-nabi":
+			json.NewEncoder(w).Encode(Identity{UserID: "u1", Username: "nabi", Role: "member", Permissions: []string{"join_rooms"}})
+		case "Bearer bht_dj":
+			json.NewEncoder(w).Encode(Identity{UserID: "u3", Username: "dj", Role: "bot", Permissions: []string{"join_rooms"}, Bot: true})
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+	mux.HandleFunc("GET /api/v1/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLoginThenMe(t *testing.T) {
+	srv := newTestServer(t)
+	c := New(srv.URL+"/", WithHTTPClient(srv.Client()))
+	ctx := context.Background()
+
+	if err := c.Login(ctx, "nabi", "secret"); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	id, err := c.Me(ctx)
+	if err != nil {
+		t.Fatalf("me: %v", err)
+	}
+	if id.Username != "nabi" || id.Role != "member" || id.Bot || len(id.Permissions) != 1 {
+		t.Fatalf("unexpected identity: %+v", id)
+	}
+}
+
+func TestMeWithToken(t *testing.T) {
+	srv := newTestServer(t)
+	c := New(srv.URL, WithToken("bht_dj"), WithHTTPClient(srv.Client()))
+
+	id, err := c.Me(context.Background())
+	if err != nil {
+		t.Fatalf("me: %v", err)
+	}
+	if id.Username != "dj" || !id.Bot {
+		t.Fatalf("unexpected identity: %+v", id)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := newTestServer(t)
+	c := New(srv.URL, WithHTTPClient(srv.Client()))
+	ctx := context.Background()
+
+	var apiErr *Error
+	err := c.Login(ctx, "nabi", "wrong")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "invalid username or password" {
+		t.Fatalf("expected login error with server message, got %v", err)
+	}
+
+	// no body: the message falls back to the status text
+	_, err = c.Me(ctx)
+	if !errors.As(err, &apiErr) || apiErr.Message != http.StatusText(http.StatusUnauthorized) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+
+	if err := c.Health(ctx); err != nil {
+		t.Fatalf("health: %v", err)
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"github.com/nabidam/baaham/internal/domain"
 )
 
-func GenerateToken(userID string, username string, isAdmin bool, secret []byte, expiration time.Duration) (string, error) {
+// GenerateToken only carries the user's ID and username. Role and
+// permissions are loaded on every request, so changes to them take effect
+// immediately instead of when the token expires.
+func GenerateToken(userID string, username string, secret []byte, expiration time.Duration) (string, error) {
 	claims := domain.UserClaims{
 		UserID:   userID,
 		Username: username,
-		IsAdmin:  isAdmin,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -22,3 +24,14 @@ func GenerateToken(userID string, username string, isAdmin bool, secret []byte,
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(secret)
 }
+
+func ParseToken(tokenString string, secret []byte) (*domain.UserClaims, error) {
+	claims := &domain.UserClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(limit int, per time.Duration) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(limit, per)
+	l.now = clock.now
+	l.lastSweep = clock.t
+	return l, clock
+}
+
+func TestAllowBurstThenWait(t *testing.T) {
+	l, _ := newTestLimiter(3, time.Minute)
+
+	for i := range 3 {
+		if ok, _ := l.Allow("k"); !ok {
+			t.Fatalf("attempt %d: expected allowed within burst", i+1)
+		}
+	}
+
+	ok, wait := l.Allow("k")
+	if ok {
+		t.Fatal("expected 4th attempt to be limited")
+	}
+	// 3 per minute refills one token every 20s
+	if wait != 20*time.Second {
+		t.Fatalf("expected wait of 20s, got %s", wait)
+	}
+}
+
+func TestAllowRefills(t *testing.T) {
+	l, clock := newTestLimiter(3, time.Minute)
+
+	for range 3 {
+		l.Allow("k")
+	}
+
+	clock.advance(10 * time.Second)
+	ok, wait := l.Allow("k")
+	if ok {
+		t.Fatal("expected limit after half a refill interval")
+	}
+	if wait != 10*time.Second {
+		t.Fatalf("expected remaining wait of 10s, got %s", wait)
+	}
+
+	clock.advance(10 * time.Second)
+	if ok, _ := l.Allow("k"); !ok {
+		t.Fatal("expected a token after a full refill interval")
+	}
+	if ok, _ := l.Allow("k"); ok {
+		t.Fatal("expected only one token to have refilled")
+	}
+}
+
+func TestAllowRefillCapsAtBurst(t *testing.T) {
+	l, clock := newTestLimiter(2, time.Minute)
+
+	l.Allow("k")
+	clock.advance(time.Hour)
+
+	for i := range 2 {
+		if ok, _ := l.Allow("k"); !ok {
+			t.Fatalf("attempt %d: expected allowed", i+1)
+		}
+	}
+	if ok, _ := l.Allow("k"); ok {
+		t.Fatal("expected refill to stop at burst")
+	}
+}
+
+func TestAllowKeysAreIndependent(t *testing.T) {
+	l, _ := newTestLimiter(1, time.Minute)
+
+	if ok, _ := l.Allow("a"); !ok {
+		t.Fatal("expected a to be allowed")
+	}
+	if ok, _ := l.Allow("b"); !ok {
+		t.Fatal("expected b to be allowed despite a being exhausted")
+	}
+	if ok, _ := l.Allow("a"); ok {
+		t.Fatal("expected a to be limited")
+	}
+}
+
+func TestSweepDropsRefilledBuckets(t *testing.T) {
+	l, clock := newTestLimiter(2, time.Minute)
+
+	l.Allow("idle")
+	clock.advance(30 * time.Second)
+	l.Allow("recent")
+
+	// the sweep runs once a full refill (60s) has passed since the last one
+	clock.advance(31 * time.Second)
+	l.Allow("trigger")
+
+	if _, ok := l.buckets["idle"]; ok {
+		t.Error("expected idle bucket to be swept")
+	}
+	if _, ok := l.buckets["recent"]; !ok {
+		t.Error("expected recently used bucket to be kept")
+	}
+	if _, ok := l.buckets["trigger"]; !ok {
+		t.Error("expected bucket created by the sweeping call to exist")
+	}
+}